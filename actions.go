@@ -0,0 +1,395 @@
+package goselenium
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// elementReferenceKey is the W3C well-known property used to serialize a
+// Element reference inside a JSON payload, e.g. as an Actions "origin" or
+// an ExecuteScript argument.
+const elementReferenceKey = "element-6066-11e4-a52e-4f735466cecf"
+
+// elementReference serializes el as a W3C element reference object.
+func elementReference(el Element) map[string]string {
+	return map[string]string{elementReferenceKey: el.ID()}
+}
+
+// Input source type constants for the W3C Actions API.
+const (
+	actionSourceKey     = "key"
+	actionSourcePointer = "pointer"
+	actionSourceWheel   = "wheel"
+)
+
+// Pointer types for a "pointer" input source.
+const (
+	PointerMouse = "mouse"
+	PointerPen   = "pen"
+	PointerTouch = "touch"
+)
+
+// Pointer origins for a pointerMove action.
+const (
+	OriginViewport = "viewport"
+	OriginPointer  = "pointer"
+)
+
+// Mouse button constants for pointerDown/pointerUp actions.
+const (
+	ButtonLeft   = 0
+	ButtonMiddle = 1
+	ButtonRight  = 2
+)
+
+// actionSource accumulates the ticks for a single W3C input source (key,
+// pointer or wheel) so they can be emitted in one request.
+type actionSource struct {
+	id         string
+	sourceType string
+	parameters map[string]interface{}
+	actions    []map[string]interface{}
+}
+
+// ActionSequence builds a sequence of W3C input actions (pointer moves and
+// clicks, key presses, wheel scrolls) and emits them as a single POST to
+// /session/{id}/actions, mirroring Selenium's Actions builder. Obtain one via
+// WebDriver.Actions().
+type ActionSequence struct {
+	driver  *seleniumWebDriver
+	sources map[string]*actionSource
+	order   []string
+}
+
+// Actions returns a new, empty ActionSequence tied to this driver's session.
+func (s *seleniumWebDriver) Actions() *ActionSequence {
+	return &ActionSequence{
+		driver:  s,
+		sources: make(map[string]*actionSource),
+	}
+}
+
+func (a *ActionSequence) source(id, sourceType string, parameters map[string]interface{}) *actionSource {
+	src, ok := a.sources[id]
+	if !ok {
+		src = &actionSource{id: id, sourceType: sourceType, parameters: parameters}
+		a.sources[id] = src
+		a.order = append(a.order, id)
+	}
+	return src
+}
+
+func (a *ActionSequence) keySource() *actionSource {
+	return a.source("keyboard", actionSourceKey, nil)
+}
+
+func (a *ActionSequence) pointerSource(pointerType string) *actionSource {
+	id := "mouse"
+	if pointerType != PointerMouse {
+		id = pointerType
+	}
+	return a.source(id, actionSourcePointer, map[string]interface{}{"pointerType": pointerType})
+}
+
+func (a *ActionSequence) wheelSource() *actionSource {
+	return a.source("wheel", actionSourceWheel, nil)
+}
+
+func (a *actionSource) append(action map[string]interface{}) {
+	a.actions = append(a.actions, action)
+}
+
+// Pause inserts a no-op tick of the given duration (in milliseconds) on
+// every currently registered source, keeping them in lockstep.
+func (a *ActionSequence) Pause(durationMS int) *ActionSequence {
+	for _, id := range a.order {
+		a.sources[id].append(map[string]interface{}{"type": "pause", "duration": durationMS})
+	}
+	return a
+}
+
+// KeyDown appends a keyDown action for the given key (e.g. "" for
+// Control) to the keyboard source.
+func (a *ActionSequence) KeyDown(key string) *ActionSequence {
+	a.keySource().append(map[string]interface{}{"type": "keyDown", "value": key})
+	return a
+}
+
+// KeyUp appends a keyUp action for the given key to the keyboard source.
+func (a *ActionSequence) KeyUp(key string) *ActionSequence {
+	a.keySource().append(map[string]interface{}{"type": "keyUp", "value": key})
+	return a
+}
+
+// SendKeys appends a keyDown/keyUp pair for every rune in text, emulating
+// typing it.
+func (a *ActionSequence) SendKeys(text string) *ActionSequence {
+	for _, r := range text {
+		a.KeyDown(string(r))
+		a.KeyUp(string(r))
+	}
+	return a
+}
+
+// KeyChord holds down every modifier key, presses key, then releases
+// everything in reverse order. Use it for combinations like Ctrl+Shift+T.
+func (a *ActionSequence) KeyChord(key string, modifiers ...string) *ActionSequence {
+	for _, m := range modifiers {
+		a.KeyDown(m)
+	}
+	a.KeyDown(key)
+	a.KeyUp(key)
+	for i := len(modifiers) - 1; i >= 0; i-- {
+		a.KeyUp(modifiers[i])
+	}
+	return a
+}
+
+// pointerMoveOrigin builds the "origin" field for a pointerMove or scroll
+// action: the viewport when el is nil, otherwise a reference to el.
+func pointerMoveOrigin(el Element) interface{} {
+	if el == nil {
+		return OriginViewport
+	}
+	return elementReference(el)
+}
+
+// MoveByOffset moves the mouse pointer by (dx, dy) pixels relative to its
+// current position.
+func (a *ActionSequence) MoveByOffset(dx, dy int) *ActionSequence {
+	a.pointerSource(PointerMouse).append(map[string]interface{}{
+		"type":     "pointerMove",
+		"duration": 0,
+		"origin":   OriginPointer,
+		"x":        dx,
+		"y":        dy,
+	})
+	return a
+}
+
+// moveTo moves the mouse pointer to the center of el.
+func (a *ActionSequence) moveTo(el Element) *ActionSequence {
+	a.pointerSource(PointerMouse).append(map[string]interface{}{
+		"type":     "pointerMove",
+		"duration": 0,
+		"origin":   pointerMoveOrigin(el),
+		"x":        0,
+		"y":        0,
+	})
+	return a
+}
+
+// PointerDown appends a pointerDown action for the given mouse button
+// (ButtonLeft, ButtonMiddle or ButtonRight).
+func (a *ActionSequence) PointerDown(button int) *ActionSequence {
+	a.pointerSource(PointerMouse).append(map[string]interface{}{"type": "pointerDown", "button": button})
+	return a
+}
+
+// PointerUp appends a pointerUp action for the given mouse button.
+func (a *ActionSequence) PointerUp(button int) *ActionSequence {
+	a.pointerSource(PointerMouse).append(map[string]interface{}{"type": "pointerUp", "button": button})
+	return a
+}
+
+// Click moves to el and performs a left-button click on it.
+func (a *ActionSequence) Click(el Element) *ActionSequence {
+	return a.moveTo(el).PointerDown(ButtonLeft).PointerUp(ButtonLeft)
+}
+
+// DoubleClick moves to el and performs two left-button clicks on it.
+func (a *ActionSequence) DoubleClick(el Element) *ActionSequence {
+	return a.moveTo(el).
+		PointerDown(ButtonLeft).PointerUp(ButtonLeft).
+		PointerDown(ButtonLeft).PointerUp(ButtonLeft)
+}
+
+// ContextClick moves to el and performs a right-button click on it.
+func (a *ActionSequence) ContextClick(el Element) *ActionSequence {
+	return a.moveTo(el).PointerDown(ButtonRight).PointerUp(ButtonRight)
+}
+
+// DragAndDrop moves to from, holds the left button down, moves to to, then
+// releases the button.
+func (a *ActionSequence) DragAndDrop(from, to Element) *ActionSequence {
+	return a.moveTo(from).PointerDown(ButtonLeft).moveTo(to).PointerUp(ButtonLeft)
+}
+
+// Scroll appends a wheel scroll of (deltaX, deltaY) originating at el. Pass a
+// nil el to scroll relative to the viewport.
+func (a *ActionSequence) Scroll(el Element, deltaX, deltaY int) *ActionSequence {
+	a.wheelSource().append(map[string]interface{}{
+		"type":     "scroll",
+		"duration": 0,
+		"origin":   pointerMoveOrigin(el),
+		"deltaX":   deltaX,
+		"deltaY":   deltaY,
+	})
+	return a
+}
+
+// Perform sends the accumulated ticks to the driver as a single
+// /session/{id}/actions request and clears the sequence. In JSON Wire mode,
+// where the actions endpoint does not exist, it instead falls back to the
+// legacy moveto/buttondown/buttonup/keys endpoints, replaying each action in
+// order.
+func (a *ActionSequence) Perform() error {
+	if a.driver.sessionID == "" {
+		return newSessionIDError("Perform")
+	}
+	defer func() {
+		a.sources = make(map[string]*actionSource)
+		a.order = nil
+	}()
+
+	if a.driver.protocol == ProtocolJSONWire {
+		return a.performLegacy()
+	}
+
+	actions := make([]map[string]interface{}, 0, len(a.order))
+	for _, id := range a.order {
+		src := a.sources[id]
+		action := map[string]interface{}{
+			"id":      src.id,
+			"type":    src.sourceType,
+			"actions": src.actions,
+		}
+		if src.parameters != nil {
+			action["parameters"] = src.parameters
+		}
+		actions = append(actions, action)
+	}
+
+	bJSON, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return newMarshallingError(err, "Perform", actions)
+	}
+
+	_, err = a.driver.valueRequest(&request{
+		url:           a.driver.seleniumURL + "/session/" + a.driver.sessionID + "/actions",
+		method:        "POST",
+		body:          bytes.NewReader(bJSON),
+		callingMethod: "Perform",
+	})
+	return err
+}
+
+// performLegacy replays the recorded actions against the pre-W3C
+// /moveto, /buttondown, /buttonup and /keys endpoints for grids that predate
+// the Actions API.
+func (a *ActionSequence) performLegacy() error {
+	for _, id := range a.order {
+		src := a.sources[id]
+		switch src.sourceType {
+		case actionSourcePointer:
+			for _, act := range src.actions {
+				var err error
+				switch act["type"] {
+				case "pointerMove":
+					err = a.legacyMoveTo(act)
+				case "pointerDown":
+					err = a.legacyButton("/buttondown")
+				case "pointerUp":
+					err = a.legacyButton("/buttonup")
+				}
+				if err != nil {
+					return err
+				}
+			}
+		case actionSourceKey:
+			if err := a.legacySendKeys(src); err != nil {
+				return err
+			}
+		case actionSourceWheel:
+			return fmt.Errorf("goselenium: wheel/scroll actions have no equivalent under the JSON Wire Protocol")
+		}
+	}
+	return nil
+}
+
+// legacySendKeys replays a keyboard source's keyDown actions against the
+// JSON Wire /session/{id}/keys endpoint, which sends a full sequence of keys
+// to whichever element currently has focus.
+func (a *ActionSequence) legacySendKeys(src *actionSource) error {
+	var keys []string
+	for _, act := range src.actions {
+		if act["type"] != "keyDown" {
+			continue
+		}
+		if v, ok := act["value"].(string); ok {
+			keys = append(keys, v)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	bJSON, err := json.Marshal(map[string]interface{}{"value": keys})
+	if err != nil {
+		return newMarshallingError(err, "Perform", keys)
+	}
+
+	_, err = a.driver.valueRequest(&request{
+		url:           a.driver.seleniumURL + "/session/" + a.driver.sessionID + "/keys",
+		method:        "POST",
+		body:          bytes.NewReader(bJSON),
+		callingMethod: "Perform",
+	})
+	return err
+}
+
+func (a *ActionSequence) legacyMoveTo(act map[string]interface{}) error {
+	body := map[string]interface{}{}
+	if ref, ok := act["origin"].(map[string]string); ok {
+		// With no offsets, JSON Wire moves to the element's center, as a W3C
+		// pointerMove with an element origin and x=y=0 does.
+		body["element"] = ref[elementReferenceKey]
+	} else {
+		if x, ok := act["x"]; ok {
+			body["xoffset"] = x
+		}
+		if y, ok := act["y"]; ok {
+			body["yoffset"] = y
+		}
+	}
+
+	bJSON, err := json.Marshal(body)
+	if err != nil {
+		return newMarshallingError(err, "Perform", body)
+	}
+
+	_, err = a.driver.valueRequest(&request{
+		url:           a.driver.seleniumURL + "/session/" + a.driver.sessionID + "/moveto",
+		method:        "POST",
+		body:          bytes.NewReader(bJSON),
+		callingMethod: "Perform",
+	})
+	return err
+}
+
+func (a *ActionSequence) legacyButton(path string) error {
+	_, err := a.driver.valueRequest(&request{
+		url:           a.driver.seleniumURL + "/session/" + a.driver.sessionID + path,
+		method:        "POST",
+		body:          bytes.NewReader([]byte("{}")),
+		callingMethod: "Perform",
+	})
+	return err
+}
+
+// Release clears any keys or buttons left depressed by a prior sequence by
+// issuing a DELETE to /session/{id}/actions.
+func (s *seleniumWebDriver) Release() error {
+	if s.sessionID == "" {
+		return newSessionIDError("Release")
+	}
+
+	_, err := s.valueRequest(&request{
+		url:           s.seleniumURL + "/session/" + s.sessionID + "/actions",
+		method:        "DELETE",
+		body:          nil,
+		callingMethod: "Release",
+	})
+	return err
+}
@@ -0,0 +1,96 @@
+package goselenium
+
+import (
+	"testing"
+)
+
+/*
+	Perform() Tests
+*/
+
+func Test_Perform_InvalidSessionIdResultsInError(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+
+	err := d.Actions().Click(newSeleniumElement(d, "abc-123")).Perform()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_Perform_LegacyFallbackSendsKeysUnderJSONWire(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": "8"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.protocol = ProtocolJSONWire
+
+	err := d.Actions().SendKeys("hi").Perform()
+	if err != nil {
+		t.Errorf("Expected the legacy /keys fallback to succeed, got: %v", err)
+	}
+}
+
+func Test_Perform_LegacyFallbackRejectsWheelUnderJSONWire(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": "8"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.protocol = ProtocolJSONWire
+
+	err := d.Actions().Scroll(nil, 0, 10).Perform()
+	if err == nil {
+		t.Errorf("Expected wheel/scroll actions to be rejected under the JSON Wire Protocol")
+	}
+}
+
+/*
+	Release() Tests
+*/
+
+func Test_Release_InvalidSessionIdResultsInError(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+
+	err := d.Release()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_Release_CorrectResponseIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": "8"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	if err := d.Release(); err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
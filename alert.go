@@ -0,0 +1,65 @@
+package goselenium
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// AlertTextResponse is returned by AlertText and SendAlertText.
+type AlertTextResponse struct {
+	State string
+	Text  string
+}
+
+// alertTextPath returns the path segment used to read or set the current
+// alert's text, which differs between the W3C and JSON Wire protocols.
+func (s *seleniumWebDriver) alertTextPath() string {
+	if s.protocol == ProtocolJSONWire {
+		return "/alert_text"
+	}
+	return "/alert/text"
+}
+
+// AlertText retrieves the text of the currently open alert, confirm or
+// prompt dialog.
+func (s *seleniumWebDriver) AlertText() (*AlertTextResponse, error) {
+	if s.sessionID == "" {
+		return nil, newSessionIDError("AlertText")
+	}
+
+	resp, err := s.valueRequest(&request{
+		url:           s.seleniumURL + "/session/" + s.sessionID + s.alertTextPath(),
+		method:        "GET",
+		callingMethod: "AlertText",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlertTextResponse{State: resp.State, Text: resp.Value}, nil
+}
+
+// SendAlertText sets the value of a prompt() dialog's text field, so that a
+// subsequent AcceptAlert submits it.
+func (s *seleniumWebDriver) SendAlertText(text string) (*AlertTextResponse, error) {
+	if s.sessionID == "" {
+		return nil, newSessionIDError("SendAlertText")
+	}
+
+	b, err := json.Marshal(map[string]interface{}{"text": text})
+	if err != nil {
+		return nil, newMarshallingError(err, "SendAlertText", text)
+	}
+
+	resp, err := s.valueRequest(&request{
+		url:           s.seleniumURL + "/session/" + s.sessionID + s.alertTextPath(),
+		method:        "POST",
+		body:          bytes.NewReader(b),
+		callingMethod: "SendAlertText",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlertTextResponse{State: resp.State, Text: resp.Value}, nil
+}
@@ -0,0 +1,146 @@
+// Package conditions provides ready-made goselenium.Condition builders for
+// use with WebDriver.Wait, mirroring the expected-conditions helpers found in
+// other Selenium client libraries.
+package conditions
+
+import (
+	"regexp"
+
+	"github.com/xu001186/go-selenium"
+)
+
+// retriable reports whether err represents a transient "not yet" state
+// (the element/alert isn't there yet) rather than a fatal failure, so Wait
+// should keep polling instead of giving up.
+func retriable(err error) bool {
+	if err == nil {
+		return false
+	}
+	return goselenium.IsNoSuchElementError(err) ||
+		goselenium.IsStaleElementReferenceError(err) ||
+		goselenium.IsNoSuchAlertError(err)
+}
+
+// ElementPresent waits until an element located by by exists in the DOM.
+func ElementPresent(by goselenium.By) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		_, err := d.FindElement(by)
+		if err != nil {
+			if retriable(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// ElementClickable waits until an element located by by exists and is
+// enabled.
+func ElementClickable(by goselenium.By) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		el, err := d.FindElement(by)
+		if err != nil {
+			if retriable(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		resp, err := el.Enabled()
+		if err != nil {
+			if retriable(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return resp.Enabled, nil
+	}
+}
+
+// ElementTextIs waits until the element located by by has exactly the text
+// want.
+func ElementTextIs(by goselenium.By, want string) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		el, err := d.FindElement(by)
+		if err != nil {
+			if retriable(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		resp, err := el.Text()
+		if err != nil {
+			if retriable(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return resp.Text == want, nil
+	}
+}
+
+// TitleIs waits until the page title is exactly want.
+func TitleIs(want string) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		resp, err := d.Title()
+		if err != nil {
+			return false, err
+		}
+		return resp.Title == want, nil
+	}
+}
+
+// URLMatches waits until the current URL matches pattern.
+func URLMatches(pattern *regexp.Regexp) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		resp, err := d.CurrentURL()
+		if err != nil {
+			return false, err
+		}
+		return pattern.MatchString(resp.URL), nil
+	}
+}
+
+// AlertPresent waits until a JavaScript alert, confirm or prompt is open.
+func AlertPresent() goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		_, err := d.AlertText()
+		if err != nil {
+			if goselenium.IsNoSuchAlertError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// NumberOfWindowsIs waits until the driver reports exactly n open windows.
+func NumberOfWindowsIs(n int) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		resp, err := d.WindowHandles()
+		if err != nil {
+			return false, err
+		}
+		return len(resp.Handles) == n, nil
+	}
+}
+
+// StalenessOf waits until el is no longer attached to the DOM.
+func StalenessOf(el goselenium.Element) goselenium.Condition {
+	return func(d goselenium.WebDriver) (bool, error) {
+		_, err := el.Enabled()
+		if err != nil {
+			if goselenium.IsStaleElementReferenceError(err) {
+				return true, nil
+			}
+			if retriable(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return false, nil
+	}
+}
@@ -4,15 +4,49 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 )
 
+// Protocol identifies which WebDriver wire protocol a seleniumWebDriver
+// speaks to its remote end. ProtocolAuto (the default) detects the protocol
+// from the shape of the first response the driver parses: a top-level
+// "status" field means JSON Wire, otherwise W3C. The capabilities in the
+// NewSession response are not inspected.
+type Protocol int
+
+const (
+	// ProtocolAuto detects the protocol from the NewSession response. This
+	// is the default used by NewSeleniumWebDriver.
+	ProtocolAuto Protocol = iota
+	// ProtocolJSONWire forces the legacy Selenium JSON Wire Protocol, where
+	// responses are shaped as {"status": <int>, "value": ...}.
+	ProtocolJSONWire
+	// ProtocolW3C forces the W3C WebDriver protocol, where responses are
+	// shaped as {"value": ...} and errors use string error codes.
+	ProtocolW3C
+)
+
+// DriverOption configures optional behaviour of a seleniumWebDriver. Use
+// these with NewSeleniumWebDriver.
+type DriverOption func(*seleniumWebDriver)
+
+// WithProtocol pins the wire protocol a driver uses instead of letting it be
+// auto-detected from the NewSession response. This is useful when talking to
+// older Selenium grids whose responses lack the top-level "status" field
+// ProtocolAuto relies on.
+func WithProtocol(p Protocol) DriverOption {
+	return func(s *seleniumWebDriver) {
+		s.protocol = p
+	}
+}
+
 // NewSeleniumWebDriver creates a new instance of a Selenium web driver with a
 // service URL (usually http://domain:port/wd/hub) and a Capabilities object.
 // This method will return validation errors if the Selenium URL is invalid or
 // the required capabilities (BrowserName) are not set.
-func NewSeleniumWebDriver(serviceURL string, capabilities Capabilities) (WebDriver, error) {
+func NewSeleniumWebDriver(serviceURL string, capabilities Capabilities, opts ...DriverOption) (WebDriver, error) {
 	if serviceURL == "" {
 		return nil, errors.New("Provided Selenium URL is invalid")
 	}
@@ -36,6 +70,11 @@ func NewSeleniumWebDriver(serviceURL string, capabilities Capabilities) (WebDriv
 		seleniumURL:  serviceURL,
 		capabilities: &capabilities,
 		apiService:   &seleniumAPIService{},
+		protocol:     ProtocolAuto,
+	}
+
+	for _, opt := range opts {
+		opt(driver)
 	}
 
 	return driver, nil
@@ -124,23 +163,116 @@ type seleniumWebDriver struct {
 	sessionID    string
 	capabilities *Capabilities
 	apiService   apiServicer
+	protocol     Protocol
 }
 
 func (s *seleniumWebDriver) DriverURL() string {
 	return s.seleniumURL
 }
 
+// detectProtocol inspects a raw response body and, as long as the driver is
+// still on ProtocolAuto, records whether the remote end speaks the W3C
+// protocol or the legacy JSON Wire Protocol. Every stateRequest/valueRequest
+// call routes its response through this before deciding how to parse it, so
+// the driver self-detects from whatever response it sees first - ordinarily
+// the NewSession response - without requiring callers to pin a protocol up
+// front. JSON Wire responses always carry a top-level "status" field; W3C
+// responses never do.
+func (s *seleniumWebDriver) detectProtocol(resp []byte) {
+	if s.protocol != ProtocolAuto {
+		return
+	}
+
+	var top map[string]json.RawMessage
+	if json.Unmarshal(resp, &top) != nil {
+		return
+	}
+
+	if _, hasStatus := top["status"]; hasStatus {
+		s.protocol = ProtocolJSONWire
+		return
+	}
+
+	s.protocol = ProtocolW3C
+}
+
+// w3cErrorValue models the "value" object returned alongside a failing W3C
+// command, e.g. {"error": "no such element", "message": "...", "stacktrace": "..."}.
+type w3cErrorValue struct {
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	Stacktrace string `json:"stacktrace"`
+}
+
+// w3cEnvelope models the outermost shape of every W3C WebDriver response:
+// a single "value" field holding either the result or an error object.
+type w3cEnvelope struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// translateW3CError maps a W3C string error code to one of the driver's
+// typed errors, preserving the message and stacktrace the remote end
+// returned.
+func translateW3CError(callingMethod string, errVal w3cErrorValue) error {
+	switch errVal.Error {
+	case "no such element":
+		return newNoSuchElementError(callingMethod, errVal.Message, errVal.Stacktrace)
+	case "stale element reference":
+		return newStaleElementReferenceError(callingMethod, errVal.Message, errVal.Stacktrace)
+	case "element click intercepted":
+		return newElementClickInterceptedError(callingMethod, errVal.Message, errVal.Stacktrace)
+	case "no such alert":
+		return newNoSuchAlertError(callingMethod, errVal.Message, errVal.Stacktrace)
+	case "unexpected alert open":
+		return newUnexpectedAlertOpenError(callingMethod, errVal.Message, errVal.Stacktrace)
+	case "invalid selector":
+		return newInvalidSelectorError(callingMethod, errVal.Message, errVal.Stacktrace)
+	case "session not created":
+		return newSessionNotCreatedError(callingMethod, errVal.Message, errVal.Stacktrace)
+	default:
+		return newUnhandledError(callingMethod, errVal.Message, errVal.Stacktrace)
+	}
+}
+
+// asW3CError checks whether a raw "value" payload is a W3C error object and,
+// if so, returns the translated typed error. ok is false when the payload is
+// a successful result instead of an error.
+func asW3CError(callingMethod string, value json.RawMessage) (err error, ok bool) {
+	var errVal w3cErrorValue
+	if json.Unmarshal(value, &errVal) != nil || errVal.Error == "" {
+		return nil, false
+	}
+
+	return translateW3CError(callingMethod, errVal), true
+}
+
 func (s *seleniumWebDriver) stateRequest(req *request) (*stateResponse, error) {
 	response := &stateResponse{
 		Status: -1,
 	}
-	var err error
 
 	resp, err := s.apiService.performRequest(req.url, req.method, req.body)
 	if err != nil {
 		return nil, newCommunicationError(err, req.callingMethod, req.url, resp)
 	}
 
+	s.detectProtocol(resp)
+
+	if s.protocol == ProtocolW3C {
+		var envelope w3cEnvelope
+		if err = json.Unmarshal(resp, &envelope); err != nil {
+			return nil, newUnmarshallingError(err, req.callingMethod, string(resp))
+		}
+
+		if w3cErr, isErr := asW3CError(req.callingMethod, envelope.Value); isErr {
+			return nil, w3cErr
+		}
+
+		response.State = "success"
+		response.Status = 0
+		return response, nil
+	}
+
 	err = json.Unmarshal(resp, response)
 	if err != nil {
 		return nil, newUnmarshallingError(err, req.callingMethod, string(resp))
@@ -192,13 +324,31 @@ func (s *seleniumWebDriver) convertStatusToStat(status int) string {
 
 func (s *seleniumWebDriver) valueRequest(req *request) (*valueResponse, error) {
 	var response valueResponse
-	var err error
 
 	resp, err := s.apiService.performRequest(req.url, req.method, req.body)
 	if err != nil {
 		return nil, newCommunicationError(err, req.callingMethod, req.url, resp)
 	}
 
+	s.detectProtocol(resp)
+
+	if s.protocol == ProtocolW3C {
+		var envelope w3cEnvelope
+		if err = json.Unmarshal(resp, &envelope); err != nil {
+			return nil, newUnmarshallingError(err, req.callingMethod, string(resp))
+		}
+
+		if w3cErr, isErr := asW3CError(req.callingMethod, envelope.Value); isErr {
+			return nil, w3cErr
+		}
+
+		response.State = "success"
+		if err = json.Unmarshal(envelope.Value, &response.Value); err != nil {
+			return nil, newUnmarshallingError(err, req.callingMethod, string(resp))
+		}
+		return &response, nil
+	}
+
 	err = json.Unmarshal(resp, &response)
 	if err != nil {
 		return nil, newUnmarshallingError(err, req.callingMethod, string(resp))
@@ -207,10 +357,30 @@ func (s *seleniumWebDriver) valueRequest(req *request) (*valueResponse, error) {
 	return &response, nil
 }
 
+// w3cBy translates a locator strategy that is only meaningful under the
+// legacy JSON Wire Protocol into its W3C equivalent. "index" has no W3C
+// counterpart and is dropped, while "id" is remapped to an attribute CSS
+// selector since W3C removed the dedicated "id" strategy.
+func w3cBy(b By) (strategy string, value interface{}) {
+	switch b.Type() {
+	case "id":
+		return "css selector", fmt.Sprintf("[id=%q]", b.Value())
+	case "index":
+		return "", nil
+	default:
+		return b.Type(), b.Value()
+	}
+}
+
 func (s *seleniumWebDriver) elementRequest(req *elRequest) ([]byte, error) {
+	strategy, value := req.by.Type(), req.by.Value()
+	if s.protocol == ProtocolW3C {
+		strategy, value = w3cBy(req.by)
+	}
+
 	b := map[string]interface{}{
-		"using": req.by.Type(),
-		"value": req.by.Value(),
+		"using": strategy,
+		"value": value,
 	}
 	bJSON, err := json.Marshal(b)
 	if err != nil {
@@ -226,29 +396,6 @@ func (s *seleniumWebDriver) elementRequest(req *elRequest) ([]byte, error) {
 	return resp, nil
 }
 
-func (s *seleniumWebDriver) scriptRequest(script string, url string, method string) (*ExecuteScriptResponse, error) {
-	r := map[string]interface{}{
-		"script": script,
-		"args":   []string{""},
-	}
-	b, err := json.Marshal(r)
-	if err != nil {
-		return nil, newMarshallingError(err, method, r)
-	}
-	body := bytes.NewReader(b)
-	resp, err := s.valueRequest(&request{
-		url:           url,
-		method:        "POST",
-		body:          body,
-		callingMethod: method,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &ExecuteScriptResponse{State: resp.State, Response: resp.Value}, nil
-}
-
 type timeout struct {
 	timeoutType string
 	timeout     int
@@ -135,4 +135,176 @@ func Test_AlertAcceptAlert_CorrectResponseIsReturned(t *testing.T) {
 	if err != nil || resp.State != "success" {
 		t.Errorf(correctResponseErrorText)
 	}
+}
+
+/*
+	AlertText() Tests
+*/
+
+func Test_AlertAlertText_InvalidSessionIdResultsInError(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+
+	_, err := d.AlertText()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_AlertAlertText_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :<"),
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.AlertText()
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_AlertAlertText_UnmarshallingErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "Invalid JSON!",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.AlertText()
+	if err == nil || !IsUnmarshallingError(err) {
+		t.Errorf(unmarshallingErrorText)
+	}
+}
+
+func Test_AlertAlertText_CorrectResponseIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": "Are you sure?"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.AlertText()
+	if err != nil || resp.State != "success" || resp.Text != "Are you sure?" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	SendAlertText() Tests
+*/
+
+func Test_AlertSendAlertText_InvalidSessionIdResultsInError(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+
+	_, err := d.SendAlertText("hello")
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_AlertSendAlertText_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :<"),
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SendAlertText("hello")
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_AlertSendAlertText_UnmarshallingErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "Invalid JSON!",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SendAlertText("hello")
+	if err == nil || !IsUnmarshallingError(err) {
+		t.Errorf(unmarshallingErrorText)
+	}
+}
+
+func Test_AlertSendAlertText_CorrectResponseIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": "8"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.SendAlertText("hello")
+	if err != nil || resp.State != "success" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	W3C protocol decoding regression tests
+*/
+
+func Test_AlertAlertText_W3CResponseValueIsDecodedNotStringified(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"value": "Are you sure?"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.protocol = ProtocolW3C
+
+	resp, err := d.AlertText()
+	if err != nil || resp.Text != "Are you sure?" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_AlertSendAlertText_W3CResponseValueIsDecodedNotStringified(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"value": "8"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.protocol = ProtocolW3C
+
+	resp, err := d.SendAlertText("hello")
+	if err != nil || resp.Text != "8" {
+		t.Errorf(correctResponseErrorText)
+	}
 }
\ No newline at end of file
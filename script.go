@@ -0,0 +1,231 @@
+package goselenium
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ExecuteScriptResponse is returned by ExecuteScript and ExecuteScriptAsync.
+type ExecuteScriptResponse struct {
+	State    string
+	Response string
+	// Result is the script's return value with any W3C element reference
+	// objects rehydrated into Elements bound to the current session, so
+	// callers can chain further calls (e.g. .Click()) on it directly.
+	Result interface{}
+}
+
+// ScriptArgMarshaler lets a user-defined type control how it is serialized
+// when passed as an ExecuteScript/ExecuteScriptAsync argument.
+type ScriptArgMarshaler interface {
+	MarshalScriptArg() (interface{}, error)
+}
+
+// scriptPath returns the path segment used to execute a script, which
+// differs between the W3C and JSON Wire protocols.
+func (s *seleniumWebDriver) scriptPath(async bool) string {
+	if s.protocol == ProtocolJSONWire {
+		if async {
+			return "/execute_async"
+		}
+		return "/execute"
+	}
+	if async {
+		return "/execute/async"
+	}
+	return "/execute/sync"
+}
+
+// ExecuteScript runs script in the context of the current page, passing args
+// as its arguments (available via the JS `arguments` array). Primitives,
+// slices and maps are marshalled as plain JSON; a Element is marshalled
+// as a W3C element reference so the script can refer to the element the
+// driver already located.
+func (s *seleniumWebDriver) ExecuteScript(script string, args ...interface{}) (*ExecuteScriptResponse, error) {
+	if s.sessionID == "" {
+		return nil, newSessionIDError("ExecuteScript")
+	}
+
+	return s.scriptRequest(script, s.seleniumURL+"/session/"+s.sessionID+s.scriptPath(false), "ExecuteScript", args...)
+}
+
+// ExecuteScriptAsync runs script asynchronously: the script receives an
+// additional final argument, a callback it must invoke with the eventual
+// result.
+func (s *seleniumWebDriver) ExecuteScriptAsync(script string, args ...interface{}) (*ExecuteScriptResponse, error) {
+	if s.sessionID == "" {
+		return nil, newSessionIDError("ExecuteScriptAsync")
+	}
+
+	return s.scriptRequest(script, s.seleniumURL+"/session/"+s.sessionID+s.scriptPath(true), "ExecuteScriptAsync", args...)
+}
+
+// marshalScriptArg converts a single ExecuteScript argument into its JSON
+// wire form, recursing into slices and maps and special-casing Element
+// references.
+func (s *seleniumWebDriver) marshalScriptArg(arg interface{}) (interface{}, error) {
+	switch v := arg.(type) {
+	case nil, string, bool, int, int32, int64, float32, float64:
+		return v, nil
+	case ScriptArgMarshaler:
+		return v.MarshalScriptArg()
+	case Element:
+		ref := map[string]interface{}{elementReferenceKey: v.ID()}
+		if s.protocol == ProtocolJSONWire {
+			ref["ELEMENT"] = v.ID()
+		}
+		return ref, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			marshalled, err := s.marshalScriptArg(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = marshalled
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			marshalled, err := s.marshalScriptArg(item)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = marshalled
+		}
+		return out, nil
+	default:
+		return s.marshalScriptArgReflect(arg)
+	}
+}
+
+// marshalScriptArgReflect handles concretely-typed slices and maps (e.g.
+// []Element, map[string]string) that don't match the []interface{}/
+// map[string]interface{} cases above, recursing element-by-element so
+// Elements nested inside them still round-trip as element references.
+func (s *seleniumWebDriver) marshalScriptArgReflect(arg interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(arg)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			marshalled, err := s.marshalScriptArg(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out[i] = marshalled
+		}
+		return out, nil
+	case reflect.Map:
+		out := make(map[string]interface{}, rv.Len())
+		for _, key := range rv.MapKeys() {
+			marshalled, err := s.marshalScriptArg(rv.MapIndex(key).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(key.Interface())] = marshalled
+		}
+		return out, nil
+	default:
+		return arg, nil
+	}
+}
+
+func (s *seleniumWebDriver) marshalScriptArgs(args []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, len(args))
+	for i, arg := range args {
+		marshalled, err := s.marshalScriptArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = marshalled
+	}
+	return out, nil
+}
+
+// rehydrateScriptValue walks a decoded script return value, turning any
+// object shaped like a W3C (or legacy JSON Wire) element reference into a
+// Element bound to the current session.
+func (s *seleniumWebDriver) rehydrateScriptValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if id, ok := val[elementReferenceKey].(string); ok {
+			return newSeleniumElement(s, id)
+		}
+		if id, ok := val["ELEMENT"].(string); ok {
+			return newSeleniumElement(s, id)
+		}
+
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = s.rehydrateScriptValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = s.rehydrateScriptValue(item)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+type scriptValueResponse struct {
+	State string          `json:"state"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (s *seleniumWebDriver) scriptRequest(script string, url string, method string, args ...interface{}) (*ExecuteScriptResponse, error) {
+	marshalledArgs, err := s.marshalScriptArgs(args)
+	if err != nil {
+		return nil, newMarshallingError(err, method, args)
+	}
+
+	r := map[string]interface{}{
+		"script": script,
+		"args":   marshalledArgs,
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, newMarshallingError(err, method, r)
+	}
+
+	resp, err := s.apiService.performRequest(url, "POST", bytes.NewReader(b))
+	if err != nil {
+		return nil, newCommunicationError(err, method, url, resp)
+	}
+
+	s.detectProtocol(resp)
+
+	var response scriptValueResponse
+	if s.protocol == ProtocolW3C {
+		var envelope w3cEnvelope
+		if err = json.Unmarshal(resp, &envelope); err != nil {
+			return nil, newUnmarshallingError(err, method, string(resp))
+		}
+		if w3cErr, isErr := asW3CError(method, envelope.Value); isErr {
+			return nil, w3cErr
+		}
+		response = scriptValueResponse{State: "success", Value: envelope.Value}
+	} else if err = json.Unmarshal(resp, &response); err != nil {
+		return nil, newUnmarshallingError(err, method, string(resp))
+	}
+
+	var decoded interface{}
+	if len(response.Value) > 0 {
+		if err = json.Unmarshal(response.Value, &decoded); err != nil {
+			return nil, newUnmarshallingError(err, method, string(response.Value))
+		}
+	}
+
+	return &ExecuteScriptResponse{
+		State:    response.State,
+		Response: string(response.Value),
+		Result:   s.rehydrateScriptValue(decoded),
+	}, nil
+}
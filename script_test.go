@@ -0,0 +1,121 @@
+package goselenium
+
+import (
+	"errors"
+	"testing"
+)
+
+/*
+	ExecuteScript() Tests
+*/
+
+func Test_ExecuteScript_InvalidSessionIdResultsInError(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+
+	_, err := d.ExecuteScript("return 1;")
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_ExecuteScript_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :<"),
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.ExecuteScript("return 1;")
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_ExecuteScript_UnmarshallingErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "Invalid JSON!",
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.ExecuteScript("return 1;")
+	if err == nil || !IsUnmarshallingError(err) {
+		t.Errorf(unmarshallingErrorText)
+	}
+}
+
+func Test_ExecuteScript_PrimitiveArgsAreMarshalled(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": 3
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScript("return arguments[0] + arguments[1];", 1, 2)
+	if err != nil || resp.State != "success" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if num, ok := resp.Result.(float64); !ok || num != 3 {
+		t.Errorf("Expected the decoded result to be the number 3, got %#v", resp.Result)
+	}
+}
+
+func Test_ExecuteScript_SliceOfElementsIsMarshalledAsElementReferences(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	el := newSeleniumElement(d, "abc-123")
+	marshalled, err := d.marshalScriptArgs([]interface{}{[]Element{el}})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling args: %v", err)
+	}
+
+	elements, ok := marshalled[0].([]interface{})
+	if !ok || len(elements) != 1 {
+		t.Fatalf("expected a one-element slice, got %#v", marshalled[0])
+	}
+
+	ref, ok := elements[0].(map[string]interface{})
+	if !ok || ref[elementReferenceKey] != "abc-123" {
+		t.Errorf("expected a W3C element reference for the Element, got %#v", elements[0])
+	}
+}
+
+func Test_ExecuteScript_ElementReferencesAreRehydrated(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": {
+				"element-6066-11e4-a52e-4f735466cecf": "abc-123"
+			}
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScript("return document.querySelector('a');")
+	if err != nil || resp.State != "success" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	el, ok := resp.Result.(Element)
+	if !ok || el.ID() != "abc-123" {
+		t.Errorf("Expected the script result to rehydrate into a Element, got %#v", resp.Result)
+	}
+}
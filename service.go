@@ -0,0 +1,250 @@
+package goselenium
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// ServiceOption configures optional behaviour of a Service created by
+// NewChromeDriverService, NewGeckoDriverService or NewSeleniumServerService.
+type ServiceOption func(*Service)
+
+// WithPort pins the port the managed driver listens on. If omitted, a free
+// port is chosen automatically.
+func WithPort(port int) ServiceOption {
+	return func(s *Service) {
+		s.port = port
+	}
+}
+
+// WithBinary overrides the path to the driver (or java) executable.
+func WithBinary(path string) ServiceOption {
+	return func(s *Service) {
+		s.binary = path
+	}
+}
+
+// WithArgs appends extra command-line arguments passed to the driver
+// process.
+func WithArgs(args []string) ServiceOption {
+	return func(s *Service) {
+		s.args = append(s.args, args...)
+	}
+}
+
+// WithEnv sets extra environment variables ("KEY=VALUE") for the driver
+// process, in addition to the current process's environment.
+func WithEnv(env []string) ServiceOption {
+	return func(s *Service) {
+		s.env = append(s.env, env...)
+	}
+}
+
+// WithOutput directs the driver process's combined stdout/stderr to w
+// instead of discarding it.
+func WithOutput(w io.Writer) ServiceOption {
+	return func(s *Service) {
+		s.output = w
+	}
+}
+
+// WithStartTimeout overrides how long Start waits for the driver's /status
+// endpoint to report ready before giving up.
+func WithStartTimeout(d time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.startTimeout = d
+	}
+}
+
+// WithXvfb spawns an Xvfb process on a free display number and injects
+// DISPLAY=:N into the driver's environment, so headed browsers can run
+// without a physical display (e.g. in CI). If Xvfb cannot be started -
+// including on platforms where it isn't supported at all - Start logs the
+// failure to the configured output and proceeds without a virtual
+// framebuffer instead of failing outright.
+func WithXvfb() ServiceOption {
+	return func(s *Service) {
+		s.useXvfb = true
+	}
+}
+
+// Service manages the lifecycle of a local chromedriver, geckodriver or
+// selenium-server process: starting it, waiting for it to become ready, and
+// stopping it again. Create one with NewChromeDriverService,
+// NewGeckoDriverService or NewSeleniumServerService.
+type Service struct {
+	binary       string
+	args         []string
+	env          []string
+	port         int
+	output       io.Writer
+	startTimeout time.Duration
+	statusPath   string
+
+	useXvfb bool
+	xvfb    *exec.Cmd
+
+	// portAfterArgs places the --port flag after the rest of args instead
+	// of before them. chromedriver/geckodriver parse --port as one of their
+	// own flags and accept it anywhere, but java itself does not - for
+	// `java -jar selenium-server.jar`, --port must come after "-jar
+	// jarPath" so it is parsed as a jar argument rather than a JVM option.
+	portAfterArgs bool
+
+	cmd *exec.Cmd
+}
+
+// NewChromeDriverService creates a Service that manages a chromedriver
+// process found at path.
+func NewChromeDriverService(path string, opts ...ServiceOption) (*Service, error) {
+	return newService(path, "/status", opts)
+}
+
+// NewGeckoDriverService creates a Service that manages a geckodriver
+// process found at path.
+func NewGeckoDriverService(path string, opts ...ServiceOption) (*Service, error) {
+	return newService(path, "/status", opts)
+}
+
+// NewSeleniumServerService creates a Service that manages a selenium-server
+// standalone jar, run as `java -jar jarPath`.
+func NewSeleniumServerService(jarPath, javaPath string, opts ...ServiceOption) (*Service, error) {
+	s, err := newService(javaPath, "/wd/hub/status", opts)
+	if err != nil {
+		return nil, err
+	}
+	s.args = append([]string{"-jar", jarPath}, s.args...)
+	s.portAfterArgs = true
+	return s, nil
+}
+
+func newService(binary, statusPath string, opts []ServiceOption) (*Service, error) {
+	s := &Service{
+		binary:       binary,
+		output:       ioutil.Discard,
+		startTimeout: 20 * time.Second,
+		statusPath:   statusPath,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.port == 0 {
+		port, err := freePort()
+		if err != nil {
+			return nil, err
+		}
+		s.port = port
+	}
+
+	return s, nil
+}
+
+// freePort asks the OS to bind an ephemeral TCP port, reads back which one
+// it chose, then releases it so the driver process can bind it instead.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("goselenium: failed to find a free port: %w", err)
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// URL returns the base URL (e.g. http://localhost:9515) the managed driver
+// listens on. It is only meaningful once Start has succeeded.
+func (s *Service) URL() string {
+	return fmt.Sprintf("http://localhost:%d", s.port)
+}
+
+// FrameBuffer returns the *exec.Cmd of the Xvfb process started by
+// WithXvfb, or nil if that option was not used. Callers that need to
+// terminate Xvfb independently of Stop can use this; ordinarily Stop handles
+// it.
+func (s *Service) FrameBuffer() *exec.Cmd {
+	return s.xvfb
+}
+
+// Start spawns the driver executable, tails its output to the configured
+// writer, and blocks until its status endpoint reports ready or
+// WithStartTimeout elapses.
+func (s *Service) Start() error {
+	env := append(os.Environ(), s.env...)
+
+	if s.useXvfb {
+		display, xvfb, err := startXvfb(s.output)
+		if err != nil {
+			fmt.Fprintf(s.output, "goselenium: WithXvfb: %v; continuing without a virtual framebuffer\n", err)
+		} else {
+			s.xvfb = xvfb
+			env = append(env, "DISPLAY="+display)
+		}
+	}
+
+	portArg := fmt.Sprintf("--port=%d", s.port)
+	var args []string
+	if s.portAfterArgs {
+		args = append(append([]string{}, s.args...), portArg)
+	} else {
+		args = append([]string{portArg}, s.args...)
+	}
+	cmd := exec.Command(s.binary, args...)
+	cmd.Env = env
+	cmd.Stdout = s.output
+	cmd.Stderr = s.output
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("goselenium: failed to start %s: %w", s.binary, err)
+	}
+	s.cmd = cmd
+
+	return s.waitForReady()
+}
+
+func (s *Service) waitForReady() error {
+	deadline := time.Now().Add(s.startTimeout)
+	statusURL := s.URL() + s.statusPath
+
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(statusURL)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return fmt.Errorf("goselenium: %s did not become ready within %s", s.binary, s.startTimeout)
+}
+
+// Stop sends SIGTERM to the driver process, then SIGKILL after a short
+// grace period if it has not exited. It also tears down any Xvfb process
+// started by WithXvfb.
+func (s *Service) Stop() error {
+	if s.xvfb != nil {
+		stopProcess(s.xvfb, 2*time.Second)
+		s.xvfb = nil
+	}
+
+	if s.cmd == nil {
+		return nil
+	}
+
+	return stopProcess(s.cmd, 5*time.Second)
+}
+
+// WebDriver wires this service's URL into NewSeleniumWebDriver, returning a
+// driver ready to use once Start has succeeded.
+func (s *Service) WebDriver(capabilities Capabilities, opts ...DriverOption) (WebDriver, error) {
+	return NewSeleniumWebDriver(s.URL(), capabilities, opts...)
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package goselenium
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// stopProcess sends SIGTERM to cmd's process and waits up to grace for it to
+// exit, sending SIGKILL if it is still running afterwards.
+func stopProcess(cmd *exec.Cmd, grace time.Duration) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		return cmd.Process.Kill()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(grace):
+		return cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package goselenium
+
+import (
+	"os/exec"
+	"time"
+)
+
+// stopProcess kills cmd's process. Windows has no SIGTERM equivalent for
+// arbitrary processes, so Stop goes straight to a hard kill.
+func stopProcess(cmd *exec.Cmd, grace time.Duration) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,41 @@
+package goselenium
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+	Service.Start() Tests
+*/
+
+func Test_ServiceStart_TimesOutWhenStatusEndpointNeverReady(t *testing.T) {
+	s, err := NewChromeDriverService("sleep", WithArgs([]string{"1"}), WithStartTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error constructing the service: %v", err)
+	}
+
+	err = s.Start()
+	if err == nil {
+		t.Fatalf("Expected Start to time out since \"sleep\" never serves a status endpoint")
+	}
+	if !strings.Contains(err.Error(), "did not become ready") {
+		t.Errorf("Expected a ready-timeout error, got: %v", err)
+	}
+}
+
+/*
+	Service.Stop() Tests
+*/
+
+func Test_ServiceStop_IsANoOpBeforeStart(t *testing.T) {
+	s, err := NewChromeDriverService("sleep")
+	if err != nil {
+		t.Fatalf("unexpected error constructing the service: %v", err)
+	}
+
+	if err := s.Stop(); err != nil {
+		t.Errorf("Expected Stop to be a no-op before Start, got: %v", err)
+	}
+}
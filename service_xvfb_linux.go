@@ -0,0 +1,43 @@
+//go:build linux
+
+package goselenium
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// startXvfb launches an Xvfb process on the first free display number it can
+// bind, returning the DISPLAY value (e.g. ":99") to inject into a driver's
+// environment.
+func startXvfb(output io.Writer) (display string, cmd *exec.Cmd, err error) {
+	num, err := freeDisplayNumber()
+	if err != nil {
+		return "", nil, err
+	}
+
+	display = fmt.Sprintf(":%d", num)
+	cmd = exec.Command("Xvfb", display, "-screen", "0", "1920x1080x24")
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	if err := cmd.Start(); err != nil {
+		return "", nil, err
+	}
+
+	return display, cmd, nil
+}
+
+// freeDisplayNumber finds an X display number that is not already bound by
+// checking for the Unix socket Xvfb would create at /tmp/.X11-unix/X<n>.
+func freeDisplayNumber() (int, error) {
+	for n := 99; n < 199; n++ {
+		socket := fmt.Sprintf("/tmp/.X11-unix/X%d", n)
+		if _, err := os.Stat(socket); os.IsNotExist(err) {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("goselenium: no free X display number found")
+}
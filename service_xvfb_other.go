@@ -0,0 +1,15 @@
+//go:build !linux
+
+package goselenium
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// startXvfb is only supported on Linux; elsewhere it returns an error, which
+// Start writes to the configured output before continuing without Xvfb.
+func startXvfb(output io.Writer) (display string, cmd *exec.Cmd, err error) {
+	return "", nil, fmt.Errorf("goselenium: WithXvfb is only supported on linux")
+}
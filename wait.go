@@ -0,0 +1,67 @@
+package goselenium
+
+import (
+	"fmt"
+	"time"
+)
+
+// Condition is a predicate evaluated repeatedly by Wait. It reports whether
+// the awaited state has been reached; a non-nil error aborts the wait
+// immediately instead of retrying, unless the condition itself chooses to
+// swallow transient errors (see the conditions sub-package).
+type Condition func(WebDriver) (bool, error)
+
+// Wait polls cond every interval until it reports true or timeout elapses,
+// returning a timeout error (see IsTimeoutError) in that case. If cond ever returns a non-nil
+// error, Wait aborts immediately and returns that error instead of
+// retrying, since by convention (see the conditions sub-package) only
+// non-retriable failures should reach Wait as an error in the first place.
+func (s *seleniumWebDriver) Wait(cond Condition, timeout, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		ok, err := cond(s)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return newTimeoutError("Wait", timeout, interval)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// SetImplicitWait is a thin wrapper over SessionImplicitWaitTimeout that
+// applies the session's implicit element-lookup wait.
+func (s *seleniumWebDriver) SetImplicitWait(d time.Duration) error {
+	_, err := s.SetSessionTimeout(SessionImplicitWaitTimeout(int(d / time.Millisecond)))
+	return err
+}
+
+// timeoutError is returned by Wait when a Condition never became true before
+// its deadline elapsed.
+type timeoutError struct {
+	callingMethod string
+	timeout       time.Duration
+	interval      time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("%s: condition was not met within %s (polling every %s)", e.callingMethod, e.timeout, e.interval)
+}
+
+func newTimeoutError(callingMethod string, timeout, interval time.Duration) error {
+	return &timeoutError{callingMethod: callingMethod, timeout: timeout, interval: interval}
+}
+
+// IsTimeoutError reports whether err was returned because a Wait condition
+// did not become true before its deadline.
+func IsTimeoutError(err error) bool {
+	_, ok := err.(*timeoutError)
+	return ok
+}
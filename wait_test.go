@@ -0,0 +1,60 @@
+package goselenium
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+/*
+	Wait() Tests
+*/
+
+func Test_Wait_ReturnsNilAssoonAsConditionIsTrue(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	calls := 0
+	cond := func(WebDriver) (bool, error) {
+		calls++
+		return true, nil
+	}
+
+	if err := d.Wait(cond, time.Second, time.Millisecond); err != nil {
+		t.Errorf("Expected Wait to return nil once cond is true, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected cond to be called exactly once, got %d calls", calls)
+	}
+}
+
+func Test_Wait_ReturnsTimeoutErrorWhenConditionNeverBecomesTrue(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	cond := func(WebDriver) (bool, error) {
+		return false, nil
+	}
+
+	err := d.Wait(cond, 10*time.Millisecond, time.Millisecond)
+	if err == nil || !IsTimeoutError(err) {
+		t.Errorf("Expected a TimeoutError once the deadline elapses, got: %v", err)
+	}
+}
+
+func Test_Wait_AbortsImmediatelyOnConditionError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	wantErr := errors.New("not retriable")
+	calls := 0
+	cond := func(WebDriver) (bool, error) {
+		calls++
+		return false, wantErr
+	}
+
+	err := d.Wait(cond, time.Second, time.Millisecond)
+	if err != wantErr {
+		t.Errorf("Expected Wait to return the condition's error unchanged, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected cond to be called exactly once before aborting, got %d calls", calls)
+	}
+}